@@ -0,0 +1,25 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package media
+
+// GeoJSONType is the media type for the "geojson" output format.
+// https://tools.ietf.org/html/rfc7946#section-12
+var GeoJSONType = Type{MainType: "application", SubType: "geo+json", Suffix: "geojson"}
+
+// KMLType is the media type for the "kml" output format.
+var KMLType = Type{MainType: "application", SubType: "vnd.google-earth.kml+xml", Suffix: "kml"}
+
+func init() {
+	DefaultTypes = append(DefaultTypes, GeoJSONType, KMLType)
+}
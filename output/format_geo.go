@@ -0,0 +1,46 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import "github.com/gohugoio/hugo/media"
+
+var (
+	// GeoJSONFormat registers the "geojson" output format, written as
+	// "map.geojson" with media type application/geo+json. No layout for it
+	// is included, so its content is what the site's own template for the
+	// format renders; geo.ToGeoJSON builds the RFC 7946 FeatureCollection
+	// map for such a template.
+	GeoJSONFormat = Format{
+		Name:        "GeoJSON",
+		MediaType:   media.GeoJSONType,
+		BaseName:    "map",
+		Rel:         "alternate",
+		IsPlainText: true,
+	}
+
+	// KMLFormat registers the "kml" output format, written as "places.kml".
+	// No layout for it is included either; geo.ToKML builds the KML
+	// Document for the site's own template.
+	KMLFormat = Format{
+		Name:        "KML",
+		MediaType:   media.KMLType,
+		BaseName:    "places",
+		Rel:         "alternate",
+		IsPlainText: false,
+	}
+)
+
+func init() {
+	DefaultFormats = append(DefaultFormats, GeoJSONFormat, KMLFormat)
+}
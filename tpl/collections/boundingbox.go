@@ -0,0 +1,50 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import "math"
+
+// BoundingBox returns the [minLat, minLon, maxLat, maxLon] box enclosing the
+// circle of radiusMeters centered on center, letting callers cheaply
+// pre-filter a collection with WhereInBBox before running an exact distance
+// check (e.g. with DistanceSort or the haversine-exact SpatialIndex
+// methods). The box is computed on a sphere of radius earthRadiusMeters; its
+// longitude half-width is asin(sin(d)/cos(lat)) for angular distance d, the
+// widest longitude offset the circle reaches, so no point of the circle lies
+// outside it.
+func BoundingBox(center GeoPoint, radiusMeters float64) [4]float64 {
+	latRad := center.Lat * math.Pi / 180
+	angular := radiusMeters / earthRadiusMeters
+	deltaLat := angular * 180 / math.Pi
+
+	minLat := center.Lat - deltaLat
+	maxLat := center.Lat + deltaLat
+
+	minLat = clamp(minLat, -90, 90)
+	maxLat = clamp(maxLat, -90, 90)
+
+	// Guard the longitude delta against the pole, where cos(latRad)
+	// approaches 0 and would otherwise blow deltaLon up to the whole globe.
+	// center.Lon ± 180 would wrap to the same value on both sides for any
+	// center.Lon != 0, producing a degenerate (minLon == maxLon) box instead
+	// of the full span, so return the literal (-180, 180) range directly.
+	cosLat := math.Cos(latRad)
+	if math.Abs(maxLat) >= 90 || math.Abs(minLat) >= 90 || cosLat < 1e-9 || math.Sin(angular) >= cosLat {
+		return [4]float64{minLat, -180, maxLat, 180}
+	}
+
+	deltaLon := math.Asin(math.Sin(angular)/cosLat) * 180 / math.Pi
+
+	return [4]float64{minLat, wrapLon(center.Lon - deltaLon), maxLat, wrapLon(center.Lon + deltaLon)}
+}
@@ -22,6 +22,44 @@ import (
 
 // Where returns a filtered subset of a given data type.
 func (ns *Namespace) DistanceSort(seq interface{}, fieldName interface{}, lat interface{}, lon interface{}) (interface{}, error) {
+	sortByField, ok := fieldName.(string)
+	if !ok {
+		return nil, errors.New("fieldName should be a string.")
+	}
+
+	centerLat, ok := lat.(float64)
+	if !ok {
+		return nil, errors.New("centerLat should be a float.")
+	}
+
+	centerLon, ok := lon.(float64)
+	if !ok {
+		return nil, errors.New("centerLon should be a float.")
+	}
+
+	return DistanceSortSeq(seq, sortByField, centerLat, centerLon)
+}
+
+// DistanceSortSeq sorts seq, nearest first, by the distance between
+// centerLat/centerLon and the GeoPoint found at fieldName (a dotted path, as
+// accepted by Where) on each item. It underlies the DistanceSort template
+// func and is also used directly by the "geo" namespace.
+func DistanceSortSeq(seq interface{}, fieldName string, centerLat, centerLon float64) (interface{}, error) {
+	return distanceSortSeq(seq, fieldName, centerLat, centerLon, 0)
+}
+
+// DistanceSortWithinRadiusSeq is DistanceSortSeq with an additional cutoff:
+// items further than maxRadius meters from centerLat/centerLon are dropped
+// rather than merely sorted to the end. It backs the geo.DistanceSort
+// template func, whose signature (seq, field, center, maxRadius) requires
+// the radius to actually filter, not just order, the result.
+func DistanceSortWithinRadiusSeq(seq interface{}, fieldName string, centerLat, centerLon, maxRadius float64) (interface{}, error) {
+	return distanceSortSeq(seq, fieldName, centerLat, centerLon, maxRadius)
+}
+
+// distanceSortSeq implements both DistanceSortSeq and
+// DistanceSortWithinRadiusSeq. maxRadius <= 0 means no cutoff.
+func distanceSortSeq(seq interface{}, fieldName string, centerLat, centerLon, maxRadius float64) (interface{}, error) {
 	if seq == nil {
 		return nil, errors.New("sequence must be provided")
 	}
@@ -39,59 +77,48 @@ func (ns *Namespace) DistanceSort(seq interface{}, fieldName interface{}, lat in
 		return nil, errors.New("can't sort " + reflect.ValueOf(seq).Type().String())
 	}
 
-	sortByField, ok := fieldName.(string)
-	if !ok {
-		return nil, errors.New("fieldName should be a string.")
-	}
+	// Create a list of pairs that will be used to do the sort
+	p := pairList{SortAsc: true, SliceType: reflect.SliceOf(seqv.Type().Elem())}
 
-	centerLat, ok := lat.(float64)
-	if !ok {
-		return nil, errors.New("centerLat should be a float.")
-	}
+	path := strings.Split(strings.Trim(fieldName, "."), ".")
 
-	centerLon, ok := lon.(float64)
-	if !ok {
-		return nil, errors.New("centerLon should be a float.")
-	}
+	addPair := func(value reflect.Value) error {
+		v := value
+		var err error
+		for _, elemName := range path {
+			v, err = evaluateSubElem(v, elemName)
+			if err != nil {
+				return err
+			}
+		}
 
-	// Create a list of pairs that will be used to do the sort
-	p := pairList{SortAsc: true, SliceType: reflect.SliceOf(seqv.Type().Elem())}
-	p.Pairs = make([]pair, seqv.Len())
+		point, err := NewGeoPoint(v.Interface())
+		if err != nil {
+			return err
+		}
+
+		dist := Distance(centerLat, centerLon, point.Lat, point.Lon)
+		if maxRadius > 0 && dist > maxRadius {
+			return nil
+		}
 
-	path := strings.Split(strings.Trim(sortByField, "."), ".")
+		p.Pairs = append(p.Pairs, pair{Key: reflect.ValueOf(dist), Value: value})
+		return nil
+	}
 
 	switch seqv.Kind() {
 	case reflect.Array, reflect.Slice:
 		for i := 0; i < seqv.Len(); i++ {
-			p.Pairs[i].Value = seqv.Index(i)
-			v := p.Pairs[i].Value
-			var err error
-			for _, elemName := range path {
-				v, err = evaluateSubElem(v, elemName)
-				if err != nil {
-					return nil, err
-				}
+			if err := addPair(seqv.Index(i)); err != nil {
+				return nil, err
 			}
-
-			var location map[string]interface{}
-			location = v.Interface().(map[string]interface{})
-			p.Pairs[i].Key = reflect.ValueOf(Distance(centerLat, centerLon, location["lat"].(float64), location["lon"].(float64)))
 		}
 	case reflect.Map:
 		keys := seqv.MapKeys()
 		for i := 0; i < seqv.Len(); i++ {
-			p.Pairs[i].Value = seqv.MapIndex(keys[i])
-			v := p.Pairs[i].Value
-			var err error
-			for _, elemName := range path {
-				v, err = evaluateSubElem(v, elemName)
-				if err != nil {
-					return nil, err
-				}
+			if err := addPair(seqv.MapIndex(keys[i])); err != nil {
+				return nil, err
 			}
-			var location map[string]interface{}
-			location = v.Interface().(map[string]interface{})
-			p.Pairs[i].Key = reflect.ValueOf(Distance(centerLat, centerLon, location["lat"].(float64), location["lon"].(float64)))
 		}
 	}
 
@@ -112,19 +139,10 @@ func hsin(theta float64) float64 {
 //
 // distance returned is METERS!!!!!!
 // http://en.wikipedia.org/wiki/Haversine_formula
+//
+// Distance delegates to HaversineDistance; geo.Distance exposes the other
+// formulas in DistanceFormulas for callers that need a different
+// accuracy/cost trade-off.
 func Distance(lat1, lon1, lat2, lon2 float64) float64 {
-	// convert to radians
-	// must cast radius as float to multiply later
-	var la1, lo1, la2, lo2, r float64
-	la1 = lat1 * math.Pi / 180
-	lo1 = lon1 * math.Pi / 180
-	la2 = lat2 * math.Pi / 180
-	lo2 = lon2 * math.Pi / 180
-
-	r = 6378100 // Earth radius in METERS
-
-	// calculate
-	h := hsin(la2-la1) + math.Cos(la1)*math.Cos(la2)*hsin(lo2-lo1)
-
-	return 2 * r * math.Asin(math.Sqrt(h))
+	return HaversineDistance(lat1, lon1, lat2, lon2)
 }
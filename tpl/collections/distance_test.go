@@ -0,0 +1,72 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import "testing"
+
+type distanceSortItem struct {
+	Name string
+	Geo  GeoPoint
+}
+
+func TestDistanceSortSeq(t *testing.T) {
+	items := []distanceSortItem{
+		{"london", GeoPoint{Lat: 51.507351, Lon: -0.127758}},
+		{"amsterdam", GeoPoint{Lat: 52.370216, Lon: 4.895168}},
+		{"rotterdam", GeoPoint{Lat: 51.924420, Lon: 4.477733}},
+	}
+
+	got, err := DistanceSortSeq(items, "Geo", amsterdamLat, amsterdamLon)
+	if err != nil {
+		t.Fatalf("DistanceSortSeq: %s", err)
+	}
+
+	sorted := got.([]distanceSortItem)
+	if len(sorted) != 3 {
+		t.Fatalf("got %d items, want 3", len(sorted))
+	}
+	if sorted[0].Name != "amsterdam" || sorted[1].Name != "rotterdam" || sorted[2].Name != "london" {
+		t.Fatalf("got order %v, %v, %v; want amsterdam, rotterdam, london", sorted[0].Name, sorted[1].Name, sorted[2].Name)
+	}
+}
+
+func TestDistanceSortWithinRadiusSeq(t *testing.T) {
+	items := []distanceSortItem{
+		{"london", GeoPoint{Lat: 51.507351, Lon: -0.127758}},
+		{"amsterdam", GeoPoint{Lat: 52.370216, Lon: 4.895168}},
+		{"rotterdam", GeoPoint{Lat: 51.924420, Lon: 4.477733}},
+	}
+
+	got, err := DistanceSortWithinRadiusSeq(items, "Geo", amsterdamLat, amsterdamLon, 100000)
+	if err != nil {
+		t.Fatalf("DistanceSortWithinRadiusSeq: %s", err)
+	}
+
+	sorted := got.([]distanceSortItem)
+	if len(sorted) != 2 {
+		t.Fatalf("got %d items within 100km, want 2 (amsterdam, rotterdam): %v", len(sorted), sorted)
+	}
+	if sorted[0].Name != "amsterdam" || sorted[1].Name != "rotterdam" {
+		t.Fatalf("got order %v, %v; want amsterdam, rotterdam", sorted[0].Name, sorted[1].Name)
+	}
+
+	// A maxRadius of 0 means no cutoff.
+	got, err = DistanceSortWithinRadiusSeq(items, "Geo", amsterdamLat, amsterdamLon, 0)
+	if err != nil {
+		t.Fatalf("DistanceSortWithinRadiusSeq: %s", err)
+	}
+	if len(got.([]distanceSortItem)) != 3 {
+		t.Fatalf("maxRadius=0 should not filter, got %d items", len(got.([]distanceSortItem)))
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"errors"
+	"reflect"
+)
+
+// seqItems returns the elements of seq (an array, slice or map) as
+// reflect.Values, along with seq's element type.
+func seqItems(seq interface{}) ([]reflect.Value, reflect.Type, error) {
+	if seq == nil {
+		return nil, nil, errors.New("sequence must be provided")
+	}
+
+	seqv, isNil := indirect(reflect.ValueOf(seq))
+	if isNil {
+		return nil, nil, errors.New("can't iterate over a nil value")
+	}
+
+	var items []reflect.Value
+
+	switch seqv.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < seqv.Len(); i++ {
+			items = append(items, seqv.Index(i))
+		}
+	case reflect.Map:
+		keys := seqv.MapKeys()
+		for i := 0; i < seqv.Len(); i++ {
+			items = append(items, seqv.MapIndex(keys[i]))
+		}
+	default:
+		return nil, nil, errors.New("can't iterate over " + seqv.Type().String())
+	}
+
+	return items, seqv.Type().Elem(), nil
+}
+
+// WhereInBBox returns the subset of seq whose fieldName GeoPoint falls
+// inside the bounding box described by minLat/minLon/maxLat/maxLon. When
+// minLon > maxLon the box is taken to cross the antimeridian, e.g.
+// minLon=170, maxLon=-170 selects longitudes east of 170° or west of -170°.
+func WhereInBBox(seq interface{}, fieldName string, minLat, minLon, maxLat, maxLon float64) (interface{}, error) {
+	items, elemType, err := seqItems(seq)
+	if err != nil {
+		return nil, err
+	}
+
+	result := reflect.MakeSlice(reflect.SliceOf(elemType), 0, len(items))
+
+	for _, item := range items {
+		point, err := geoFieldFromPath(item, fieldName)
+		if err != nil {
+			return nil, err
+		}
+		if point.Lat < minLat || point.Lat > maxLat {
+			continue
+		}
+		if minLon <= maxLon {
+			if point.Lon < minLon || point.Lon > maxLon {
+				continue
+			}
+		} else {
+			// The box crosses the antimeridian.
+			if point.Lon < minLon && point.Lon > maxLon {
+				continue
+			}
+		}
+		result = reflect.Append(result, item)
+	}
+
+	return result.Interface(), nil
+}
+
+// WhereInPolygon returns the subset of seq whose fieldName GeoPoint falls
+// inside polygon, a closed or open ring of [lon, lat] vertices. Containment
+// is decided by ray casting: a point is inside if a ray cast from it (here,
+// due east) crosses an odd number of polygon edges. A vertex or edge that
+// lies exactly on the ray is treated as a crossing only when the edge's
+// lower endpoint is at the ray's latitude, the standard half-open rule used
+// to avoid double-counting edges that meet at a vertex on the ray.
+func WhereInPolygon(seq interface{}, fieldName string, polygon [][2]float64) (interface{}, error) {
+	items, elemType, err := seqItems(seq)
+	if err != nil {
+		return nil, err
+	}
+	if len(polygon) < 3 {
+		return nil, errors.New("polygon must have at least 3 vertices")
+	}
+
+	result := reflect.MakeSlice(reflect.SliceOf(elemType), 0, len(items))
+
+	for _, item := range items {
+		point, err := geoFieldFromPath(item, fieldName)
+		if err != nil {
+			return nil, err
+		}
+		if pointInPolygon(point, polygon) {
+			result = reflect.Append(result, item)
+		}
+	}
+
+	return result.Interface(), nil
+}
+
+// pointInPolygon implements the ray-casting (even-odd rule) point-in-polygon
+// test. polygon vertices are [lon, lat] pairs, matching GeoJSON order.
+func pointInPolygon(point GeoPoint, polygon [][2]float64) bool {
+	inside := false
+	n := len(polygon)
+
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		loni, lati := polygon[i][0], polygon[i][1]
+		lonj, latj := polygon[j][0], polygon[j][1]
+
+		// Does the edge (i, j) straddle the point's latitude?
+		if (lati > point.Lat) != (latj > point.Lat) {
+			// Longitude where the edge crosses point.Lat.
+			lonAtLat := loni + (point.Lat-lati)/(latj-lati)*(lonj-loni)
+			if point.Lon < lonAtLat {
+				inside = !inside
+			}
+		}
+	}
+
+	return inside
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import "testing"
+
+type filterItem struct {
+	Name string
+	Geo  GeoPoint
+}
+
+func TestWhereInBBox(t *testing.T) {
+	items := []filterItem{
+		{"in", GeoPoint{Lat: 5, Lon: 5}},
+		{"out-lat", GeoPoint{Lat: 20, Lon: 5}},
+		{"out-lon", GeoPoint{Lat: 5, Lon: 20}},
+	}
+
+	got, err := WhereInBBox(items, "Geo", 0, 0, 10, 10)
+	if err != nil {
+		t.Fatalf("WhereInBBox: %s", err)
+	}
+	result := got.([]filterItem)
+	if len(result) != 1 || result[0].Name != "in" {
+		t.Fatalf("WhereInBBox = %v, want only \"in\"", result)
+	}
+}
+
+func TestWhereInBBoxAntimeridian(t *testing.T) {
+	items := []filterItem{
+		{"east", GeoPoint{Lat: 0, Lon: 175}},
+		{"west", GeoPoint{Lat: 0, Lon: -175}},
+		{"outside", GeoPoint{Lat: 0, Lon: 0}},
+	}
+
+	// A box crossing the antimeridian: east of 170° or west of -170°.
+	got, err := WhereInBBox(items, "Geo", -10, 170, 10, -170)
+	if err != nil {
+		t.Fatalf("WhereInBBox: %s", err)
+	}
+	result := got.([]filterItem)
+	names := map[string]bool{}
+	for _, r := range result {
+		names[r.Name] = true
+	}
+	if !names["east"] || !names["west"] {
+		t.Fatalf("expected east and west inside the antimeridian-crossing box, got %v", result)
+	}
+	if names["outside"] {
+		t.Fatalf("did not expect the point at lon=0 inside the antimeridian-crossing box, got %v", result)
+	}
+}
+
+func TestWhereInPolygon(t *testing.T) {
+	square := [][2]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+
+	items := []filterItem{
+		{"in", GeoPoint{Lat: 5, Lon: 5}},
+		{"out", GeoPoint{Lat: 5, Lon: 15}},
+	}
+
+	got, err := WhereInPolygon(items, "Geo", square)
+	if err != nil {
+		t.Fatalf("WhereInPolygon: %s", err)
+	}
+	result := got.([]filterItem)
+	if len(result) != 1 || result[0].Name != "in" {
+		t.Fatalf("WhereInPolygon = %v, want only \"in\"", result)
+	}
+}
+
+func TestWhereInPolygonRequiresAtLeastATriangle(t *testing.T) {
+	if _, err := WhereInPolygon([]filterItem{}, "Geo", [][2]float64{{0, 0}, {1, 1}}); err == nil {
+		t.Fatal("expected an error for a 2-vertex polygon")
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	box := BoundingBox(GeoPoint{Lat: 0, Lon: 0}, 111195) // ~1 degree of latitude
+	withinTolerance(t, "BoundingBox minLat", box[0], -1, 0.01)
+	withinTolerance(t, "BoundingBox maxLat", box[2], 1, 0.01)
+}
+
+func TestBoundingBoxNearPole(t *testing.T) {
+	// Regression test: a box near the pole must span the full longitude
+	// range, not a degenerate sliver at center.Lon ± 180 (which wrap to the
+	// same value for any center.Lon != 0).
+	box := BoundingBox(GeoPoint{Lat: 89.9, Lon: 170}, 50000)
+
+	if box[1] != -180 || box[3] != 180 {
+		t.Fatalf("BoundingBox near the pole = %v, want full longitude span [-180, 180]", box)
+	}
+
+	// A point clearly within 50km of the pole, on the opposite side of the
+	// globe from center.Lon, must still fall inside the box.
+	items := []filterItem{{"near-pole", GeoPoint{Lat: 89.9, Lon: 100}}}
+	got, err := WhereInBBox(items, "Geo", box[0], box[1], box[2], box[3])
+	if err != nil {
+		t.Fatalf("WhereInBBox: %s", err)
+	}
+	if result := got.([]filterItem); len(result) != 1 {
+		t.Fatalf("expected the near-pole point to be inside the pre-filter box, got %v", result)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"fmt"
+	"math"
+)
+
+// DistanceFunc computes the distance, in meters, between two points given
+// in degrees.
+type DistanceFunc func(lat1, lon1, lat2, lon2 float64) float64
+
+// earthRadiusMeters is the WGS84 mean radius, used by the spherical
+// formulas (Haversine and equirectangular). Earlier versions of Distance
+// used 6378100, roughly the equatorial radius (see wgs84SemiMajorAxis),
+// which overstates distances.
+const earthRadiusMeters = 6371008.8
+
+// wgs84 ellipsoid parameters, used by Vincenty.
+const (
+	wgs84SemiMajorAxis = 6378137.0         // a
+	wgs84Flattening    = 1 / 298.257223563 // f
+)
+
+// DistanceFormulas maps the names accepted by the "formula" option of
+// geo.Distance to their implementation.
+var DistanceFormulas = map[string]DistanceFunc{
+	"haversine":       HaversineDistance,
+	"equirectangular": EquirectangularDistance,
+	"vincenty":        VincentyDistance,
+}
+
+// HaversineDistance returns the great-circle distance between two points
+// using the Haversine formula on a sphere of radius earthRadiusMeters.
+// http://en.wikipedia.org/wiki/Haversine_formula
+func HaversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	la1 := lat1 * math.Pi / 180
+	lo1 := lon1 * math.Pi / 180
+	la2 := lat2 * math.Pi / 180
+	lo2 := lon2 * math.Pi / 180
+
+	h := hsin(la2-la1) + math.Cos(la1)*math.Cos(la2)*hsin(lo2-lo1)
+
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(h))
+}
+
+// EquirectangularDistance approximates the distance between two nearby
+// points by projecting them onto a plane: x = Δλ·cos((φ1+φ2)/2), y = Δφ.
+// It is considerably cheaper than Haversine and accurate enough over the
+// short distances typical of e.g. "places near me" queries, but should not
+// be trusted across large distances or near the poles.
+func EquirectangularDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	la1 := lat1 * math.Pi / 180
+	lo1 := lon1 * math.Pi / 180
+	la2 := lat2 * math.Pi / 180
+	lo2 := lon2 * math.Pi / 180
+
+	x := (lo2 - lo1) * math.Cos((la1+la2)/2)
+	y := la2 - la1
+
+	return math.Sqrt(x*x+y*y) * earthRadiusMeters
+}
+
+// VincentyDistance returns the geodesic distance between two points on the
+// WGS84 ellipsoid using Vincenty's inverse formula, iterating over U1, U2
+// and L until λ converges to within 1e-12 radians or 200 iterations have
+// passed (which happens for near-antipodal points, where Vincenty's
+// iteration doesn't converge; the last computed value is returned in that
+// case rather than an error, matching the common practical workaround).
+// https://en.wikipedia.org/wiki/Vincenty%27s_formulae
+func VincentyDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	const a = wgs84SemiMajorAxis
+	const f = wgs84Flattening
+	b := (1 - f) * a
+
+	φ1 := lat1 * math.Pi / 180
+	φ2 := lat2 * math.Pi / 180
+	L := (lon2 - lon1) * math.Pi / 180
+
+	U1 := math.Atan((1 - f) * math.Tan(φ1))
+	U2 := math.Atan((1 - f) * math.Tan(φ2))
+	sinU1, cosU1 := math.Sin(U1), math.Cos(U1)
+	sinU2, cosU2 := math.Sin(U2), math.Cos(U2)
+
+	λ := L
+	var sinσ, cosσ, σ, sinα, cos2α, cos2σm, C float64
+
+	for i := 0; i < 200; i++ {
+		sinλ, cosλ := math.Sin(λ), math.Cos(λ)
+		sinσ = math.Sqrt(math.Pow(cosU2*sinλ, 2) + math.Pow(cosU1*sinU2-sinU1*cosU2*cosλ, 2))
+		if sinσ == 0 {
+			return 0 // coincident points
+		}
+		cosσ = sinU1*sinU2 + cosU1*cosU2*cosλ
+		σ = math.Atan2(sinσ, cosσ)
+		sinα = cosU1 * cosU2 * sinλ / sinσ
+		cos2α = 1 - sinα*sinα
+		if cos2α != 0 {
+			cos2σm = cosσ - 2*sinU1*sinU2/cos2α
+		} else {
+			cos2σm = 0 // equatorial line
+		}
+		C = f / 16 * cos2α * (4 + f*(4-3*cos2α))
+		λPrev := λ
+		λ = L + (1-C)*f*sinα*(σ+C*sinσ*(cos2σm+C*cosσ*(-1+2*cos2σm*cos2σm)))
+		if math.Abs(λ-λPrev) < 1e-12 {
+			break
+		}
+	}
+
+	u2 := cos2α * (a*a - b*b) / (b * b)
+	A := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	B := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+	Δσ := B * sinσ * (cos2σm + B/4*(cosσ*(-1+2*cos2σm*cos2σm)-B/6*cos2σm*(-3+4*sinσ*sinσ)*(-3+4*cos2σm*cos2σm)))
+
+	return b * A * (σ - Δσ)
+}
+
+// DistanceUnit converts a meters distance into the given unit. The
+// recognized units are "m"/"meters", "km"/"kilometers" and "mi"/"miles";
+// an unrecognized unit is an error rather than a silent no-op.
+func DistanceUnit(meters float64, unit string) (float64, error) {
+	switch unit {
+	case "", "m", "meters":
+		return meters, nil
+	case "km", "kilometers":
+		return meters / 1000, nil
+	case "mi", "miles":
+		return meters / 1609.344, nil
+	default:
+		return 0, fmt.Errorf("geo: unrecognized unit %q", unit)
+	}
+}
+
+// DistanceOptions are the options accepted by the geo.Distance template
+// func's trailing dict argument.
+type DistanceOptions struct {
+	Formula string
+	Unit    string
+}
+
+// DistanceWithOptions returns the distance between two points, in opts.Unit
+// (default meters), computed with opts.Formula (default haversine).
+func DistanceWithOptions(lat1, lon1, lat2, lon2 float64, opts DistanceOptions) (float64, error) {
+	formula := opts.Formula
+	if formula == "" {
+		formula = "haversine"
+	}
+	fn, ok := DistanceFormulas[formula]
+	if !ok {
+		return 0, fmt.Errorf("geo: unrecognized formula %q", formula)
+	}
+
+	return DistanceUnit(fn(lat1, lon1, lat2, lon2), opts.Unit)
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"math"
+	"testing"
+)
+
+// Amsterdam and London, about 357.4km apart.
+const (
+	amsterdamLat, amsterdamLon = 52.370216, 4.895168
+	londonLat, londonLon       = 51.507351, -0.127758
+)
+
+func withinTolerance(t *testing.T, name string, got, want, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("%s = %v, want %v ± %v", name, got, want, tolerance)
+	}
+}
+
+func TestHaversineDistance(t *testing.T) {
+	got := HaversineDistance(amsterdamLat, amsterdamLon, londonLat, londonLon)
+	withinTolerance(t, "HaversineDistance", got, 357365, 10)
+
+	if got := HaversineDistance(amsterdamLat, amsterdamLon, amsterdamLat, amsterdamLon); got != 0 {
+		t.Errorf("distance from a point to itself = %v, want 0", got)
+	}
+}
+
+func TestEquirectangularDistance(t *testing.T) {
+	// Accurate over short distances.
+	got := EquirectangularDistance(52.0, 4.0, 52.001, 4.001)
+	withinTolerance(t, "EquirectangularDistance (short)", got, 130.6, 1)
+
+	// Diverges a bit from the great-circle distance over longer ones, but
+	// should still be in the right ballpark.
+	got = EquirectangularDistance(amsterdamLat, amsterdamLon, londonLat, londonLon)
+	withinTolerance(t, "EquirectangularDistance (long)", got, 357450, 1000)
+}
+
+func TestVincentyDistance(t *testing.T) {
+	got := VincentyDistance(amsterdamLat, amsterdamLon, londonLat, londonLon)
+	withinTolerance(t, "VincentyDistance", got, 358444, 10)
+
+	if got := VincentyDistance(amsterdamLat, amsterdamLon, amsterdamLat, amsterdamLon); got != 0 {
+		t.Errorf("distance from a point to itself = %v, want 0", got)
+	}
+}
+
+func TestDistanceUnit(t *testing.T) {
+	for _, test := range []struct {
+		unit string
+		want float64
+	}{
+		{"", 1000},
+		{"m", 1000},
+		{"meters", 1000},
+		{"km", 1},
+		{"kilometers", 1},
+		{"mi", 1000 / 1609.344},
+	} {
+		got, err := DistanceUnit(1000, test.unit)
+		if err != nil {
+			t.Fatalf("DistanceUnit(1000, %q): unexpected error: %s", test.unit, err)
+		}
+		if got != test.want {
+			t.Errorf("DistanceUnit(1000, %q) = %v, want %v", test.unit, got, test.want)
+		}
+	}
+
+	if _, err := DistanceUnit(1000, "furlongs"); err == nil {
+		t.Fatal("expected an error for an unrecognized unit")
+	}
+}
+
+func TestDistanceWithOptions(t *testing.T) {
+	got, err := DistanceWithOptions(amsterdamLat, amsterdamLon, londonLat, londonLon, DistanceOptions{Formula: "vincenty", Unit: "km"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	withinTolerance(t, "DistanceWithOptions", got, 358.444, 0.01)
+
+	if _, err := DistanceWithOptions(0, 0, 0, 0, DistanceOptions{Formula: "not-a-formula"}); err == nil {
+		t.Fatal("expected an error for an unrecognized formula")
+	}
+}
+
+func TestDistanceDelegatesToHaversine(t *testing.T) {
+	got := Distance(amsterdamLat, amsterdamLon, londonLat, londonLon)
+	want := HaversineDistance(amsterdamLat, amsterdamLon, londonLat, londonLon)
+	if got != want {
+		t.Errorf("Distance() = %v, want %v (HaversineDistance)", got, want)
+	}
+}
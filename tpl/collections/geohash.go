@@ -0,0 +1,192 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// geohashPrecision is the number of characters used when indexing a point.
+// 12 characters is a precision of a few centimeters, far more than SpatialIndex
+// needs, but it lets the index truncate to any shorter prefix length at
+// query time without re-encoding.
+const geohashPrecision = 12
+
+// geohashCellDimensions gives the approximate width/height, in meters, of a
+// geohash cell at each prefix length (index 0 is unused).
+// https://en.wikipedia.org/wiki/Geohash#Digits_and_precision_in_km
+var geohashCellDimensions = [geohashPrecision + 1][2]float64{
+	0:  {0, 0},
+	1:  {5009400, 4992600},
+	2:  {1252300, 624100},
+	3:  {156500, 156000},
+	4:  {39100, 19500},
+	5:  {4900, 4900},
+	6:  {1200, 609.4},
+	7:  {152.9, 152.4},
+	8:  {38.2, 19},
+	9:  {4.8, 4.8},
+	10: {1.2, 0.595},
+	11: {0.149, 0.149},
+	12: {0.037, 0.019},
+}
+
+// geohashPrefixLenForRadius returns the longest geohash prefix length whose
+// cell, per geohashCellDimensions, is at least as large as radiusMeters in
+// both dimensions, so that a query circle of that radius is covered by the
+// cell itself and its 8 neighbors. The dimensions are those at the equator.
+// When even a length-1 cell is smaller than radiusMeters, it returns 1.
+func geohashPrefixLenForRadius(radiusMeters float64) int {
+	for length := 1; length <= geohashPrecision; length++ {
+		dim := geohashCellDimensions[length]
+		if dim[0] < radiusMeters || dim[1] < radiusMeters {
+			if length == 1 {
+				return 1
+			}
+			return length - 1
+		}
+	}
+	return geohashPrecision
+}
+
+// encodeGeohash encodes lat/lon into a geohash string of the given length.
+func encodeGeohash(lat, lon float64, length int) string {
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+
+	var buf []byte
+	bit, ch := 0, 0
+	evenBit := true
+
+	for len(buf) < length {
+		if evenBit {
+			mid := (lonRange[0] + lonRange[1]) / 2
+			if lon >= mid {
+				ch |= 1 << uint(4-bit)
+				lonRange[0] = mid
+			} else {
+				lonRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if lat >= mid {
+				ch |= 1 << uint(4-bit)
+				latRange[0] = mid
+			} else {
+				latRange[1] = mid
+			}
+		}
+		evenBit = !evenBit
+
+		if bit < 4 {
+			bit++
+		} else {
+			buf = append(buf, geohashBase32[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return string(buf)
+}
+
+// geohashNeighbors returns hash and its 8 surrounding cells at the same
+// prefix length, found by nudging the cell's center in each compass
+// direction and re-encoding. Out-of-range nudges near the poles or the
+// antimeridian are clamped or wrapped by clamp and wrapLon before encoding.
+func geohashNeighbors(hash string) []string {
+	lat, lon, latErr, lonErr := decodeGeohash(hash)
+	length := len(hash)
+
+	seen := map[string]bool{hash: true}
+	cells := []string{hash}
+
+	for _, d := range [][2]float64{
+		{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+		{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+	} {
+		nLat := clamp(lat+d[0]*2*latErr, -90, 90)
+		nLon := wrapLon(lon + d[1]*2*lonErr)
+		h := encodeGeohash(nLat, nLon, length)
+		if !seen[h] {
+			seen[h] = true
+			cells = append(cells, h)
+		}
+	}
+
+	return cells
+}
+
+// decodeGeohash decodes hash back to its center point plus the remaining
+// lat/lon uncertainty (half the cell's height/width).
+func decodeGeohash(hash string) (lat, lon, latErr, lonErr float64) {
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+	evenBit := true
+
+	for i := 0; i < len(hash); i++ {
+		idx := indexByte(geohashBase32, hash[i])
+		for bit := 4; bit >= 0; bit-- {
+			bitVal := (idx >> uint(bit)) & 1
+			if evenBit {
+				mid := (lonRange[0] + lonRange[1]) / 2
+				if bitVal == 1 {
+					lonRange[0] = mid
+				} else {
+					lonRange[1] = mid
+				}
+			} else {
+				mid := (latRange[0] + latRange[1]) / 2
+				if bitVal == 1 {
+					latRange[0] = mid
+				} else {
+					latRange[1] = mid
+				}
+			}
+			evenBit = !evenBit
+		}
+	}
+
+	lat = (latRange[0] + latRange[1]) / 2
+	lon = (lonRange[0] + lonRange[1]) / 2
+	latErr = (latRange[1] - latRange[0]) / 2
+	lonErr = (lonRange[1] - lonRange[0]) / 2
+	return
+}
+
+func indexByte(s string, b byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == b {
+			return i
+		}
+	}
+	return -1
+}
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func wrapLon(lon float64) float64 {
+	for lon < -180 {
+		lon += 360
+	}
+	for lon > 180 {
+		lon -= 360
+	}
+	return lon
+}
@@ -0,0 +1,94 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	// The canonical example from https://en.wikipedia.org/wiki/Geohash.
+	got := encodeGeohash(57.64911, 10.40744, 12)
+	want := "u4pruydqqvj8"
+	if got != want {
+		t.Fatalf("encodeGeohash() = %q, want %q", got, want)
+	}
+
+	if got := encodeGeohash(57.64911, 10.40744, 6); got != want[:6] {
+		t.Fatalf("encodeGeohash() prefix = %q, want %q", got, want[:6])
+	}
+}
+
+func TestDecodeGeohashRoundTrip(t *testing.T) {
+	lat, lon := 52.370216, 4.895168
+	hash := encodeGeohash(lat, lon, geohashPrecision)
+
+	decodedLat, decodedLon, latErr, lonErr := decodeGeohash(hash)
+	if d := decodedLat - lat; d > latErr || d < -latErr {
+		t.Fatalf("decoded lat %v too far from %v (errbound %v)", decodedLat, lat, latErr)
+	}
+	if d := decodedLon - lon; d > lonErr || d < -lonErr {
+		t.Fatalf("decoded lon %v too far from %v (errbound %v)", decodedLon, lon, lonErr)
+	}
+}
+
+func TestGeohashPrefixLenForRadius(t *testing.T) {
+	for _, test := range []struct {
+		radius float64
+		want   int
+	}{
+		{5000000, 1},
+		{100, 7},
+		{0.01, geohashPrecision},
+	} {
+		if got := geohashPrefixLenForRadius(test.radius); got != test.want {
+			t.Errorf("geohashPrefixLenForRadius(%v) = %d, want %d", test.radius, got, test.want)
+		}
+	}
+}
+
+func TestGeohashNeighbors(t *testing.T) {
+	hash := encodeGeohash(52.370216, 4.895168, 6)
+	neighbors := geohashNeighbors(hash)
+
+	if len(neighbors) == 0 || len(neighbors) > 9 {
+		t.Fatalf("expected between 1 and 9 cells, got %d", len(neighbors))
+	}
+
+	found := false
+	for _, n := range neighbors {
+		if n == hash {
+			found = true
+		}
+		if len(n) != len(hash) {
+			t.Errorf("neighbor %q has a different length than %q", n, hash)
+		}
+	}
+	if !found {
+		t.Fatalf("geohashNeighbors(%q) did not include the cell itself: %v", hash, neighbors)
+	}
+}
+
+func TestGeohashNeighborsNearAntimeridian(t *testing.T) {
+	// Should not panic and should only return valid base32 geohashes.
+	hash := encodeGeohash(10, 179.999, 6)
+	for _, n := range geohashNeighbors(hash) {
+		for _, c := range n {
+			if !strings.ContainsRune(geohashBase32, c) {
+				t.Fatalf("neighbor %q contains a non-geohash character %q", n, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+// GeoJSONPage is the subset of page.Page that ToGeoJSON needs to build a
+// feature's properties. It's satisfied by *hugolib.pageState without this
+// package importing hugolib (which in turn imports tpl), so it's kept
+// deliberately small rather than accepting the full page.Page interface.
+type GeoJSONPage interface {
+	Title() string
+	Permalink() string
+	Summary() interface{}
+}
+
+// ToGeoJSON builds an RFC 7946 FeatureCollection from seq, reading each
+// item's GeoPoint at fieldName. Items that don't implement GeoJSONPage
+// contribute a feature without title, permalink and summary properties
+// rather than erroring, since a geo field doesn't imply the item is a page.
+//
+// params.geo.properties, when present on an item that has a Param method,
+// is merged into the feature's properties so site authors can publish
+// arbitrary metadata (e.g. a marker icon or category) alongside the title
+// and permalink that Hugo already knows about.
+func ToGeoJSON(seq interface{}, fieldName string) (map[string]interface{}, error) {
+	items, _, err := seqItems(seq)
+	if err != nil {
+		return nil, err
+	}
+
+	features := make([]map[string]interface{}, 0, len(items))
+
+	for _, item := range items {
+		point, err := geoFieldFromPath(item, fieldName)
+		if err != nil {
+			return nil, err
+		}
+
+		properties := map[string]interface{}{}
+		iface := item.Interface()
+
+		if pg, ok := iface.(GeoJSONPage); ok {
+			properties["title"] = pg.Title()
+			properties["permalink"] = pg.Permalink()
+			properties["summary"] = pg.Summary()
+		}
+
+		if paramer, ok := iface.(interface {
+			Param(interface{}) (interface{}, error)
+		}); ok {
+			if extra, err := paramer.Param("geo.properties"); err == nil {
+				if extraMap, ok := extra.(map[string]interface{}); ok {
+					for k, v := range extraMap {
+						properties[k] = v
+					}
+				}
+			}
+		}
+
+		features = append(features, map[string]interface{}{
+			"type": "Feature",
+			"geometry": map[string]interface{}{
+				"type":        "Point",
+				"coordinates": []float64{point.Lon, point.Lat},
+			},
+			"properties": properties,
+		})
+	}
+
+	return map[string]interface{}{
+		"type":     "FeatureCollection",
+		"features": features,
+	}, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeGeoPage is a minimal stand-in for a hugolib page, implementing just
+// enough of GeoJSONPage (plus Param, for the params.geo.properties merge)
+// for ToGeoJSON/ToKML to exercise.
+type fakeGeoPage struct {
+	Title_     string
+	Permalink_ string
+	Summary_   string
+	Geo        GeoPoint
+	Params     map[string]interface{}
+}
+
+func (p fakeGeoPage) Title() string        { return p.Title_ }
+func (p fakeGeoPage) Permalink() string     { return p.Permalink_ }
+func (p fakeGeoPage) Summary() interface{} { return p.Summary_ }
+
+func (p fakeGeoPage) Param(key interface{}) (interface{}, error) {
+	if v, ok := p.Params[key.(string)]; ok {
+		return v, nil
+	}
+	return nil, errors.New("param not found")
+}
+
+func TestToGeoJSON(t *testing.T) {
+	pages := []fakeGeoPage{
+		{
+			Title_:     "Amsterdam",
+			Permalink_: "https://example.org/amsterdam/",
+			Summary_:   "The capital of the Netherlands",
+			Geo:        GeoPoint{Lat: 52.370216, Lon: 4.895168},
+			Params: map[string]interface{}{
+				"geo.properties": map[string]interface{}{"category": "city"},
+			},
+		},
+	}
+
+	got, err := ToGeoJSON(pages, "Geo")
+	if err != nil {
+		t.Fatalf("ToGeoJSON: %s", err)
+	}
+
+	if got["type"] != "FeatureCollection" {
+		t.Fatalf(`got["type"] = %v, want "FeatureCollection"`, got["type"])
+	}
+
+	features, ok := got["features"].([]map[string]interface{})
+	if !ok || len(features) != 1 {
+		t.Fatalf("got %d features, want 1", len(features))
+	}
+
+	feature := features[0]
+	if feature["type"] != "Feature" {
+		t.Errorf(`feature["type"] = %v, want "Feature"`, feature["type"])
+	}
+
+	geometry := feature["geometry"].(map[string]interface{})
+	coords := geometry["coordinates"].([]float64)
+	if len(coords) != 2 || coords[0] != 4.895168 || coords[1] != 52.370216 {
+		t.Errorf("coordinates = %v, want [lon, lat] = [4.895168, 52.370216]", coords)
+	}
+
+	properties := feature["properties"].(map[string]interface{})
+	if properties["title"] != "Amsterdam" {
+		t.Errorf(`properties["title"] = %v, want "Amsterdam"`, properties["title"])
+	}
+	if properties["permalink"] != "https://example.org/amsterdam/" {
+		t.Errorf(`properties["permalink"] = %v`, properties["permalink"])
+	}
+	if properties["category"] != "city" {
+		t.Errorf(`properties["category"] = %v, want "city" (merged from params.geo.properties)`, properties["category"])
+	}
+}
+
+func TestToGeoJSONWithoutPageInterface(t *testing.T) {
+	// A plain map with a geo field shouldn't error even though it doesn't
+	// implement GeoJSONPage; it just gets empty properties.
+	items := []map[string]interface{}{
+		{"geo": map[string]interface{}{"lat": 1.0, "lon": 2.0}},
+	}
+
+	got, err := ToGeoJSON(items, "geo")
+	if err != nil {
+		t.Fatalf("ToGeoJSON: %s", err)
+	}
+	features := got["features"].([]map[string]interface{})
+	if len(features) != 1 {
+		t.Fatalf("got %d features, want 1", len(features))
+	}
+	if len(features[0]["properties"].(map[string]interface{})) != 0 {
+		t.Errorf("expected empty properties, got %v", features[0]["properties"])
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// GeoPoint is a parsed latitude/longitude pair. It is the common currency
+// between the geo template funcs and the various shapes front matter authors
+// tend to store coordinates in.
+type GeoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+// NewGeoPoint parses v into a GeoPoint. The following representations are
+// supported:
+//
+//	[]interface{}{lon, lat}                      // GeoJSON "position"
+//	map[string]interface{}{"lat": ..., "lon": ...}  // also accepts "lng"/"long"
+//	struct{ Latitude, Longitude float64 }         // also Lat/Lon and Lat/Lng
+//	"lat,lon"                                     // a comma separated string
+//
+// An error is returned rather than panicking so callers iterating over a
+// page collection can skip or report on the offending page instead of
+// bringing down the whole build.
+func NewGeoPoint(v interface{}) (GeoPoint, error) {
+	switch vv := v.(type) {
+	case GeoPoint:
+		return vv, nil
+	case *GeoPoint:
+		return *vv, nil
+	case []interface{}:
+		return geoPointFromGeoJSON(vv)
+	case map[string]interface{}:
+		return geoPointFromMap(vv)
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(vv))
+		for k, val := range vv {
+			m[fmt.Sprint(k)] = val
+		}
+		return geoPointFromMap(m)
+	case string:
+		return geoPointFromString(vv)
+	default:
+		return geoPointFromStruct(v)
+	}
+}
+
+// geoPointFromGeoJSON accepts the GeoJSON "position" array, [lon, lat] (and
+// [lon, lat, altitude], with altitude ignored).
+func geoPointFromGeoJSON(arr []interface{}) (GeoPoint, error) {
+	if len(arr) < 2 {
+		return GeoPoint{}, fmt.Errorf("geo: need at least [lon, lat], got %v", arr)
+	}
+	lon, err := ToFloat64(arr[0])
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("geo: invalid lon: %s", err)
+	}
+	lat, err := ToFloat64(arr[1])
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("geo: invalid lat: %s", err)
+	}
+	return GeoPoint{Lat: lat, Lon: lon}, nil
+}
+
+func geoPointFromMap(m map[string]interface{}) (GeoPoint, error) {
+	latV, ok := firstOf(m, "lat", "latitude")
+	if !ok {
+		return GeoPoint{}, fmt.Errorf("geo: no lat/latitude key in %v", m)
+	}
+	lonV, ok := firstOf(m, "lon", "lng", "long", "longitude")
+	if !ok {
+		return GeoPoint{}, fmt.Errorf("geo: no lon/lng/longitude key in %v", m)
+	}
+	lat, err := ToFloat64(latV)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("geo: invalid lat: %s", err)
+	}
+	lon, err := ToFloat64(lonV)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("geo: invalid lon: %s", err)
+	}
+	return GeoPoint{Lat: lat, Lon: lon}, nil
+}
+
+func geoPointFromStruct(v interface{}) (GeoPoint, error) {
+	rv, isNil := indirect(reflect.ValueOf(v))
+	if isNil || rv.Kind() != reflect.Struct {
+		return GeoPoint{}, fmt.Errorf("geo: don't know how to read a point from %T", v)
+	}
+
+	latF := firstFieldOf(rv, "Lat", "Latitude")
+	lonF := firstFieldOf(rv, "Lon", "Lng", "Long", "Longitude")
+	if !latF.IsValid() || !lonF.IsValid() {
+		return GeoPoint{}, fmt.Errorf("geo: %T has no recognizable lat/lon fields", v)
+	}
+
+	lat, err := ToFloat64(latF.Interface())
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("geo: invalid lat: %s", err)
+	}
+	lon, err := ToFloat64(lonF.Interface())
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("geo: invalid lon: %s", err)
+	}
+	return GeoPoint{Lat: lat, Lon: lon}, nil
+}
+
+// geoPointFromString parses "lat,lon" or "lat, lon".
+func geoPointFromString(s string) (GeoPoint, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return GeoPoint{}, fmt.Errorf(`geo: expected a "lat,lon" string, got %q`, s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("geo: invalid lat in %q: %s", s, err)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return GeoPoint{}, fmt.Errorf("geo: invalid lon in %q: %s", s, err)
+	}
+	return GeoPoint{Lat: lat, Lon: lon}, nil
+}
+
+func firstOf(m map[string]interface{}, keys ...string) (interface{}, bool) {
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func firstFieldOf(rv reflect.Value, names ...string) reflect.Value {
+	for _, name := range names {
+		if f := rv.FieldByName(name); f.IsValid() {
+			return f
+		}
+	}
+	return reflect.Value{}
+}
+
+// ToFloat64 converts v, one of the numeric or string representations a
+// coordinate might arrive in from front matter, to a float64. It is shared
+// by the GeoPoint parsers and by geo.Distance, so a "52.37"-style string
+// coordinate behaves the same whichever path it comes in through.
+func ToFloat64(v interface{}) (float64, error) {
+	switch vv := v.(type) {
+	case float64:
+		return vv, nil
+	case float32:
+		return float64(vv), nil
+	case int:
+		return float64(vv), nil
+	case int64:
+		return float64(vv), nil
+	case string:
+		return strconv.ParseFloat(vv, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to float64", v)
+	}
+}
+
+// geoFieldFromPath resolves fieldName (a dotted path, as accepted by
+// DistanceSort) against v and parses the result into a GeoPoint.
+func geoFieldFromPath(v reflect.Value, fieldName string) (GeoPoint, error) {
+	path := strings.Split(strings.Trim(fieldName, "."), ".")
+	var err error
+	for _, elemName := range path {
+		v, err = evaluateSubElem(v, elemName)
+		if err != nil {
+			return GeoPoint{}, err
+		}
+	}
+	return NewGeoPoint(v.Interface())
+}
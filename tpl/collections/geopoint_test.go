@@ -0,0 +1,96 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import "testing"
+
+type geoPointStruct struct {
+	Lat float64
+	Lon float64
+}
+
+type geoPointLatLngStruct struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func TestNewGeoPoint(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		in      interface{}
+		want    GeoPoint
+		wantErr bool
+	}{
+		{"geojson array", []interface{}{4.895168, 52.370216}, GeoPoint{Lat: 52.370216, Lon: 4.895168}, false},
+		{"geojson array too short", []interface{}{4.895168}, GeoPoint{}, true},
+		{"map lat/lon", map[string]interface{}{"lat": 52.370216, "lon": 4.895168}, GeoPoint{Lat: 52.370216, Lon: 4.895168}, false},
+		{"map lat/lng", map[string]interface{}{"lat": 52.370216, "lng": 4.895168}, GeoPoint{Lat: 52.370216, Lon: 4.895168}, false},
+		{"map missing lat", map[string]interface{}{"lon": 4.895168}, GeoPoint{}, true},
+		{"yaml-style map", map[interface{}]interface{}{"lat": 52.370216, "lon": 4.895168}, GeoPoint{Lat: 52.370216, Lon: 4.895168}, false},
+		{"struct Lat/Lon", geoPointStruct{Lat: 52.370216, Lon: 4.895168}, GeoPoint{Lat: 52.370216, Lon: 4.895168}, false},
+		{"pointer to struct", &geoPointStruct{Lat: 52.370216, Lon: 4.895168}, GeoPoint{Lat: 52.370216, Lon: 4.895168}, false},
+		{"struct Latitude/Longitude", geoPointLatLngStruct{Latitude: 52.370216, Longitude: 4.895168}, GeoPoint{Lat: 52.370216, Lon: 4.895168}, false},
+		{"lat,lon string", "52.370216,4.895168", GeoPoint{Lat: 52.370216, Lon: 4.895168}, false},
+		{"lat, lon string with spaces", "52.370216, 4.895168", GeoPoint{Lat: 52.370216, Lon: 4.895168}, false},
+		{"malformed string", "not a point", GeoPoint{}, true},
+		{"unsupported type", 42, GeoPoint{}, true},
+		{"already a GeoPoint", GeoPoint{Lat: 1, Lon: 2}, GeoPoint{Lat: 1, Lon: 2}, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewGeoPoint(test.in)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Fatalf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestToFloat64(t *testing.T) {
+	for _, test := range []struct {
+		in      interface{}
+		want    float64
+		wantErr bool
+	}{
+		{1.5, 1.5, false},
+		{float32(1.5), 1.5, false},
+		{2, 2, false},
+		{int64(3), 3, false},
+		{"4.5", 4.5, false},
+		{"not a number", 0, true},
+		{true, 0, true},
+	} {
+		got, err := ToFloat64(test.in)
+		if test.wantErr {
+			if err == nil {
+				t.Fatalf("ToFloat64(%v): expected an error", test.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ToFloat64(%v): unexpected error: %s", test.in, err)
+		}
+		if got != test.want {
+			t.Fatalf("ToFloat64(%v) = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// KMLDocument is the root of a KML Document, ready to be marshaled with
+// encoding/xml. It mirrors the shape ToGeoJSON builds, but in KML's
+// Placemark vocabulary rather than GeoJSON's Feature vocabulary.
+type KMLDocument struct {
+	XMLName   xml.Name       `xml:"kml"`
+	Xmlns     string         `xml:"xmlns,attr"`
+	Placemark []KMLPlacemark `xml:"Document>Placemark"`
+}
+
+// KMLPlacemark is one <Placemark> in the document.
+type KMLPlacemark struct {
+	Name         string           `xml:"name,omitempty"`
+	Description  string           `xml:"description,omitempty"`
+	Point        KMLPoint         `xml:"Point"`
+	ExtendedData *KMLExtendedData `xml:"ExtendedData,omitempty"`
+}
+
+// KMLPoint is a <Point><coordinates>lon,lat</coordinates></Point>.
+type KMLPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// KMLExtendedData carries the params.geo.properties entries that don't have
+// a dedicated KML element.
+type KMLExtendedData struct {
+	Data []KMLData `xml:"Data"`
+}
+
+// KMLData is one <Data name="...">-<value>...</value></Data> pair.
+type KMLData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// ToKML builds a KML Document from seq, reading each item's GeoPoint at
+// fieldName. It reuses ToGeoJSON's page/property resolution so the two
+// output formats stay in lock-step.
+func ToKML(seq interface{}, fieldName string) (*KMLDocument, error) {
+	geojson, err := ToGeoJSON(seq, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	features, _ := geojson["features"].([]map[string]interface{})
+	doc := &KMLDocument{
+		Xmlns:     "http://www.opengis.net/kml/2.2",
+		Placemark: make([]KMLPlacemark, 0, len(features)),
+	}
+
+	for _, f := range features {
+		properties, _ := f["properties"].(map[string]interface{})
+		geometry, _ := f["geometry"].(map[string]interface{})
+		coords, _ := geometry["coordinates"].([]float64)
+
+		placemark := KMLPlacemark{
+			Point: KMLPoint{Coordinates: fmt.Sprintf("%g,%g", coords[0], coords[1])},
+		}
+
+		if title, ok := properties["title"].(string); ok {
+			placemark.Name = title
+		}
+		if summary, ok := properties["summary"].(string); ok {
+			placemark.Description = summary
+		}
+
+		var data []KMLData
+		for k, v := range properties {
+			switch k {
+			case "title", "summary", "permalink":
+				continue
+			}
+			data = append(data, KMLData{Name: k, Value: fmt.Sprint(v)})
+		}
+		if len(data) > 0 {
+			placemark.ExtendedData = &KMLExtendedData{Data: data}
+		}
+
+		doc.Placemark = append(doc.Placemark, placemark)
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestToKML(t *testing.T) {
+	pages := []fakeGeoPage{
+		{
+			Title_:     "Amsterdam",
+			Permalink_: "https://example.org/amsterdam/",
+			Summary_:   "The capital of the Netherlands",
+			Geo:        GeoPoint{Lat: 52.370216, Lon: 4.895168},
+			Params: map[string]interface{}{
+				"geo.properties": map[string]interface{}{"category": "city"},
+			},
+		},
+	}
+
+	doc, err := ToKML(pages, "Geo")
+	if err != nil {
+		t.Fatalf("ToKML: %s", err)
+	}
+
+	if len(doc.Placemark) != 1 {
+		t.Fatalf("got %d placemarks, want 1", len(doc.Placemark))
+	}
+
+	p := doc.Placemark[0]
+	if p.Name != "Amsterdam" {
+		t.Errorf("Name = %q, want %q", p.Name, "Amsterdam")
+	}
+	if p.Description != "The capital of the Netherlands" {
+		t.Errorf("Description = %q", p.Description)
+	}
+	if p.Point.Coordinates != "4.895168,52.370216" {
+		t.Errorf("Coordinates = %q, want %q", p.Point.Coordinates, "4.895168,52.370216")
+	}
+	if p.ExtendedData == nil || len(p.ExtendedData.Data) != 1 || p.ExtendedData.Data[0].Name != "category" {
+		t.Fatalf("ExtendedData = %+v, want a single category entry", p.ExtendedData)
+	}
+
+	out, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("xml.Marshal: %s", err)
+	}
+	if !strings.Contains(string(out), "<Placemark>") {
+		t.Errorf("marshaled KML missing <Placemark>: %s", out)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// spatialIndexEntry is one indexed item: its geohash (at full precision)
+// and the original value, kept as a reflect.Value so the index works with
+// any seq element type.
+type spatialIndexEntry struct {
+	geohash string
+	point   GeoPoint
+	value   reflect.Value
+}
+
+// SpatialIndex is a geohash-bucketed index over a page collection. Building
+// one is itself an O(N) scan, so it is only an improvement over the plain
+// DistanceSort scan-and-rank if it's built once and reused across repeated
+// queries (e.g. from a listing template rendered for every page); the geo
+// namespace's Nearest/WithinRadius methods do that by caching a SpatialIndex
+// per (seq, fieldName) pair for the life of the build. Calling
+// NewSpatialIndex directly on every query would not save anything over
+// DistanceSort.
+//
+// Points are bucketed by successively shorter geohash prefixes; WithinRadius
+// picks the longest prefix whose cell covers the requested radius (see
+// geohashPrefixLenForRadius) and only scans the query cell and its 8
+// neighbors at that prefix length.
+type SpatialIndex struct {
+	entries []spatialIndexEntry
+	// buckets maps a geohash prefix to the indexes, into entries, of the
+	// points whose full geohash has that prefix.
+	buckets map[string][]int
+}
+
+// NewSpatialIndex builds a SpatialIndex from seq, reading the GeoPoint for
+// each item at fieldName (a dotted path, as accepted by DistanceSort).
+func NewSpatialIndex(seq interface{}, fieldName string) (*SpatialIndex, error) {
+	if seq == nil {
+		return nil, errors.New("sequence must be provided")
+	}
+
+	seqv, isNil := indirect(reflect.ValueOf(seq))
+	if isNil {
+		return nil, errors.New("can't iterate over a nil value")
+	}
+
+	idx := &SpatialIndex{buckets: make(map[string][]int)}
+
+	addEntry := func(item reflect.Value) error {
+		point, err := geoFieldFromPath(item, fieldName)
+		if err != nil {
+			return err
+		}
+		hash := encodeGeohash(point.Lat, point.Lon, geohashPrecision)
+		i := len(idx.entries)
+		idx.entries = append(idx.entries, spatialIndexEntry{geohash: hash, point: point, value: item})
+		for length := 1; length <= geohashPrecision; length++ {
+			prefix := hash[:length]
+			idx.buckets[prefix] = append(idx.buckets[prefix], i)
+		}
+		return nil
+	}
+
+	switch seqv.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < seqv.Len(); i++ {
+			if err := addEntry(seqv.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Map:
+		keys := seqv.MapKeys()
+		for i := 0; i < seqv.Len(); i++ {
+			if err := addEntry(seqv.MapIndex(keys[i])); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errors.New("can't iterate over " + seqv.Type().String())
+	}
+
+	return idx, nil
+}
+
+// candidates returns the deduplicated entry indexes found in the cells
+// covering center at the given geohash prefix length (the cell itself plus
+// its 8 neighbors).
+func (idx *SpatialIndex) candidates(centerLat, centerLon float64, prefixLen int) []int {
+	hash := encodeGeohash(centerLat, centerLon, prefixLen)
+
+	seen := make(map[int]bool)
+	var out []int
+	for _, cell := range geohashNeighbors(hash) {
+		for _, i := range idx.buckets[cell] {
+			if !seen[i] {
+				seen[i] = true
+				out = append(out, i)
+			}
+		}
+	}
+	return out
+}
+
+// WithinRadius returns, in no particular order, the values whose point lies
+// within radiusMeters of center.
+func (idx *SpatialIndex) WithinRadius(center GeoPoint, radiusMeters float64) []interface{} {
+	// geohashCellDimensions holds widths at the equator; a cell's east-west
+	// width shrinks with cos(latitude), so the radius is widened by the
+	// cosine at the circle's poleward edge before picking a prefix length.
+	edgeLat := math.Min(90, math.Abs(center.Lat)+radiusMeters/earthRadiusMeters*180/math.Pi)
+	searchRadius := math.Inf(1)
+	if cosLat := math.Cos(edgeLat * math.Pi / 180); cosLat > 1e-9 {
+		searchRadius = radiusMeters / cosLat
+	}
+	prefixLen := geohashPrefixLenForRadius(searchRadius)
+
+	candidates := idx.candidates(center.Lat, center.Lon, prefixLen)
+	if dim := geohashCellDimensions[prefixLen]; dim[0] < searchRadius || dim[1] < searchRadius {
+		// Not even a length-1 cell covers the radius: check every entry.
+		candidates = make([]int, len(idx.entries))
+		for i := range candidates {
+			candidates[i] = i
+		}
+	}
+
+	var out []interface{}
+	for _, i := range candidates {
+		e := idx.entries[i]
+		if Distance(center.Lat, center.Lon, e.point.Lat, e.point.Lon) <= radiusMeters {
+			out = append(out, e.value.Interface())
+		}
+	}
+	return out
+}
+
+// Nearest returns the k values closest to center, nearest first. The search
+// starts at the full geohash precision and backs off to shorter prefixes
+// (wider cells) until at least k candidates have been gathered, or the index
+// is exhausted.
+func (idx *SpatialIndex) Nearest(center GeoPoint, k int) []interface{} {
+	if k <= 0 || len(idx.entries) == 0 {
+		return nil
+	}
+
+	prefixLen := geohashPrecision
+	var candidateIdx []int
+	for prefixLen > 0 {
+		candidateIdx = idx.candidates(center.Lat, center.Lon, prefixLen)
+		if len(candidateIdx) >= k {
+			break
+		}
+		prefixLen--
+	}
+	if prefixLen == 0 {
+		candidateIdx = make([]int, len(idx.entries))
+		for i := range idx.entries {
+			candidateIdx[i] = i
+		}
+	}
+
+	type ranked struct {
+		dist float64
+		idx  int
+	}
+	ranks := make([]ranked, len(candidateIdx))
+	for i, ci := range candidateIdx {
+		e := idx.entries[ci]
+		ranks[i] = ranked{dist: Distance(center.Lat, center.Lon, e.point.Lat, e.point.Lon), idx: ci}
+	}
+	sort.Slice(ranks, func(i, j int) bool { return ranks[i].dist < ranks[j].dist })
+
+	if k > len(ranks) {
+		k = len(ranks)
+	}
+
+	out := make([]interface{}, k)
+	for i := 0; i < k; i++ {
+		out[i] = idx.entries[ranks[i].idx].value.Interface()
+	}
+	return out
+}
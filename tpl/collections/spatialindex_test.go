@@ -0,0 +1,75 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import "testing"
+
+type spatialIndexItem struct {
+	Name string
+	Geo  GeoPoint
+}
+
+func TestSpatialIndexNearestAndWithinRadius(t *testing.T) {
+	items := []spatialIndexItem{
+		{"amsterdam", GeoPoint{Lat: 52.370216, Lon: 4.895168}},
+		{"rotterdam", GeoPoint{Lat: 51.924420, Lon: 4.477733}},
+		{"london", GeoPoint{Lat: 51.507351, Lon: -0.127758}},
+		{"sydney", GeoPoint{Lat: -33.868820, Lon: 151.209296}},
+	}
+
+	idx, err := NewSpatialIndex(items, "Geo")
+	if err != nil {
+		t.Fatalf("NewSpatialIndex: %s", err)
+	}
+
+	amsterdam := GeoPoint{Lat: 52.370216, Lon: 4.895168}
+
+	nearest := idx.Nearest(amsterdam, 2)
+	if len(nearest) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(nearest))
+	}
+	if got := nearest[0].(spatialIndexItem).Name; got != "amsterdam" {
+		t.Errorf("nearest[0] = %q, want amsterdam", got)
+	}
+	if got := nearest[1].(spatialIndexItem).Name; got != "rotterdam" {
+		t.Errorf("nearest[1] = %q, want rotterdam", got)
+	}
+
+	within := idx.WithinRadius(amsterdam, 100000) // 100km
+	names := make(map[string]bool)
+	for _, v := range within {
+		names[v.(spatialIndexItem).Name] = true
+	}
+	if !names["amsterdam"] || !names["rotterdam"] {
+		t.Fatalf("expected amsterdam and rotterdam within 100km, got %v", names)
+	}
+	if names["london"] || names["sydney"] {
+		t.Fatalf("did not expect london or sydney within 100km, got %v", names)
+	}
+}
+
+func TestSpatialIndexNearestMoreThanAvailable(t *testing.T) {
+	items := []spatialIndexItem{
+		{"amsterdam", GeoPoint{Lat: 52.370216, Lon: 4.895168}},
+	}
+	idx, err := NewSpatialIndex(items, "Geo")
+	if err != nil {
+		t.Fatalf("NewSpatialIndex: %s", err)
+	}
+
+	got := idx.Nearest(GeoPoint{Lat: 52.370216, Lon: 4.895168}, 5)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 result (len(items) < k), got %d", len(got))
+	}
+}
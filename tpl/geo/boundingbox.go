@@ -0,0 +1,30 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"github.com/gohugoio/hugo/tpl/collections"
+)
+
+// BoundingBox returns the [minLat, minLon, maxLat, maxLon] box enclosing the
+// circle of radiusMeters around center, for use with geo.Within as a cheap
+// pre-filter ahead of an exact distance check.
+func (ns *Namespace) BoundingBox(center interface{}, radiusMeters float64) ([]float64, error) {
+	p, err := collections.NewGeoPoint(center)
+	if err != nil {
+		return nil, err
+	}
+	box := collections.BoundingBox(p, radiusMeters)
+	return box[:], nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"errors"
+
+	"github.com/gohugoio/hugo/tpl/collections"
+)
+
+// Distance returns the distance between (lat1, lon1) and (lat2, lon2). An
+// optional trailing dict accepts a "formula" (one of the keys in
+// collections.DistanceFormulas; default "haversine") and a "unit" ("m"
+// (default), "km" or "mi").
+func (ns *Namespace) Distance(lat1, lon1, lat2, lon2 interface{}, opts ...map[string]interface{}) (float64, error) {
+	la1, err := collections.ToFloat64(lat1)
+	if err != nil {
+		return 0, err
+	}
+	lo1, err := collections.ToFloat64(lon1)
+	if err != nil {
+		return 0, err
+	}
+	la2, err := collections.ToFloat64(lat2)
+	if err != nil {
+		return 0, err
+	}
+	lo2, err := collections.ToFloat64(lon2)
+	if err != nil {
+		return 0, err
+	}
+
+	var distOpts collections.DistanceOptions
+	if len(opts) > 0 {
+		if v, ok := opts[0]["formula"]; ok {
+			distOpts.Formula, ok = v.(string)
+			if !ok {
+				return 0, errors.New("formula should be a string.")
+			}
+		}
+		if v, ok := opts[0]["unit"]; ok {
+			distOpts.Unit, ok = v.(string)
+			if !ok {
+				return 0, errors.New("unit should be a string.")
+			}
+		}
+	}
+
+	return collections.DistanceWithOptions(la1, lo1, la2, lo2, distOpts)
+}
+
+// DistanceSort sorts seq by the distance of fieldName from center, nearest
+// first, dropping any item further than maxRadius meters from center. Pass
+// a maxRadius of 0 (or less) for no cutoff, i.e. a plain distance sort.
+func (ns *Namespace) DistanceSort(seq, fieldName, center interface{}, maxRadius float64) (interface{}, error) {
+	sortByField, ok := fieldName.(string)
+	if !ok {
+		return nil, errors.New("fieldName should be a string.")
+	}
+
+	p, err := collections.NewGeoPoint(center)
+	if err != nil {
+		return nil, err
+	}
+	return collections.DistanceSortWithinRadiusSeq(seq, sortByField, p.Lat, p.Lon, maxRadius)
+}
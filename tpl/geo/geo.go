@@ -0,0 +1,67 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geo provides template functions for working with geographic
+// data stored in page front matter, e.g. sorting and filtering a
+// collection of pages by distance from a point.
+package geo
+
+import (
+	"sync"
+
+	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/tpl/collections"
+)
+
+// New returns a new instance of the geo-namespaced template functions.
+func New(d *deps.Deps) *Namespace {
+	return &Namespace{
+		deps:              d,
+		spatialIndexCache: make(map[spatialIndexCacheKey]spatialIndexCacheEntry),
+	}
+}
+
+// Namespace provides template functions for the "geo" namespace.
+type Namespace struct {
+	deps *deps.Deps
+
+	// spatialIndexCache holds one collections.SpatialIndex per distinct
+	// (seq, fieldName) pair seen by Nearest/WithinRadius, built lazily on
+	// first use and reused for the life of this Namespace (i.e. for the
+	// whole build), so that e.g. a "nearby places" partial rendered on
+	// every page only pays the geohash-encoding cost once rather than on
+	// every render.
+	spatialIndexCacheMu sync.Mutex
+	spatialIndexCache   map[spatialIndexCacheKey]spatialIndexCacheEntry
+}
+
+// spatialIndexCacheKey identifies a seq/fieldName pair for
+// spatialIndexCache. seq is keyed by its underlying data pointer rather
+// than by value, so it only matches repeat calls with the very same
+// collection (e.g. .Site.RegularPages passed in from different templates),
+// not merely an equal one.
+type spatialIndexCacheKey struct {
+	seq       uintptr
+	fieldName string
+}
+
+// spatialIndexCacheEntry pairs a built index with the seq it was built
+// from. Keeping seq alive here, for as long as the Namespace (and so the
+// cache) lives, means the pointer half of spatialIndexCacheKey can never be
+// reused by an unrelated, later collection while this entry still exists,
+// which rules out the ABA mismatch that keying on the pointer alone would
+// otherwise risk.
+type spatialIndexCacheEntry struct {
+	idx *collections.SpatialIndex
+	seq interface{}
+}
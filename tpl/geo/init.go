@@ -0,0 +1,85 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/tpl/internal"
+)
+
+const name = "geo"
+
+func init() {
+	f := func(d *deps.Deps) *internal.TemplateFuncsNamespace {
+		ctx := New(d)
+
+		ns := &internal.TemplateFuncsNamespace{
+			Name: name,
+			Context: func(args ...interface{}) (interface{}, error) {
+				return ctx, nil
+			},
+		}
+
+		ns.AddMethodMapping(ctx.Distance,
+			[]string{"geo.Distance"},
+			[][2]string{
+				{`{{ geo.Distance 52.370216 4.895168 51.507351 -0.127758 (dict "unit" "km") }}`, `357.36543441443143`},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.DistanceSort,
+			[]string{"geo.DistanceSort"},
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Within,
+			[]string{"geo.Within"},
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.Nearest,
+			[]string{"geo.Nearest"},
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.WithinRadius,
+			[]string{"geo.WithinRadius"},
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.WithinPolygon,
+			[]string{"geo.WithinPolygon"},
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.BoundingBox,
+			[]string{"geo.BoundingBox"},
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.ToGeoJSON,
+			[]string{"geo.ToGeoJSON"},
+			[][2]string{},
+		)
+
+		ns.AddMethodMapping(ctx.ToKML,
+			[]string{"geo.ToKML"},
+			[][2]string{},
+		)
+
+		return ns
+	}
+
+	internal.AddTemplateFuncsNamespace(f)
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"errors"
+
+	"github.com/gohugoio/hugo/tpl/collections"
+)
+
+// ToKML builds a KML Document from seq, reading each item's GeoPoint at
+// fieldName. The "kml" output format ships no layout and does not call this
+// itself; a template for that format can render the returned document.
+func (ns *Namespace) ToKML(seq, fieldName interface{}) (*collections.KMLDocument, error) {
+	sortByField, ok := fieldName.(string)
+	if !ok {
+		return nil, errors.New("fieldName should be a string.")
+	}
+	return collections.ToKML(seq, sortByField)
+}
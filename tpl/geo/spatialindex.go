@@ -0,0 +1,108 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/gohugoio/hugo/tpl/collections"
+)
+
+// Nearest returns the k items in seq closest to center, nearest first,
+// using a geohash-bucketed collections.SpatialIndex rather than scanning
+// and ranking every item by Haversine distance. The index for (seq,
+// fieldName) is built on first use and cached for the rest of the build, so
+// calling this repeatedly for the same collection (e.g. once per rendered
+// page) only pays the indexing cost once.
+func (ns *Namespace) Nearest(seq, fieldName, center interface{}, k int) (interface{}, error) {
+	idx, p, err := ns.spatialIndex(seq, fieldName, center)
+	if err != nil {
+		return nil, err
+	}
+	return idx.Nearest(p, k), nil
+}
+
+// WithinRadius returns the items in seq whose fieldName point lies within
+// radiusMeters of center.
+func (ns *Namespace) WithinRadius(seq, fieldName, center interface{}, radiusMeters float64) (interface{}, error) {
+	idx, p, err := ns.spatialIndex(seq, fieldName, center)
+	if err != nil {
+		return nil, err
+	}
+	return idx.WithinRadius(p, radiusMeters), nil
+}
+
+func (ns *Namespace) spatialIndex(seq, fieldName, center interface{}) (*collections.SpatialIndex, collections.GeoPoint, error) {
+	sortByField, ok := fieldName.(string)
+	if !ok {
+		return nil, collections.GeoPoint{}, errors.New("fieldName should be a string.")
+	}
+
+	p, err := collections.NewGeoPoint(center)
+	if err != nil {
+		return nil, collections.GeoPoint{}, err
+	}
+
+	idx, err := ns.getOrBuildSpatialIndex(seq, sortByField)
+	if err != nil {
+		return nil, collections.GeoPoint{}, err
+	}
+
+	return idx, p, nil
+}
+
+// getOrBuildSpatialIndex returns the cached SpatialIndex for (seq,
+// fieldName), building and caching it on the first call. seq must be a
+// slice, map or pointer for it to be cacheable, since the cache is keyed on
+// its data pointer; anything else (e.g. an array) falls back to building an
+// index per call.
+func (ns *Namespace) getOrBuildSpatialIndex(seq interface{}, fieldName string) (*collections.SpatialIndex, error) {
+	key, cacheable := spatialIndexCacheKeyFor(seq, fieldName)
+
+	if cacheable {
+		ns.spatialIndexCacheMu.Lock()
+		entry, found := ns.spatialIndexCache[key]
+		ns.spatialIndexCacheMu.Unlock()
+		// A subslice such as seq[:n] shares its parent's data pointer, so a
+		// hit also has to match seq's length.
+		v := reflect.ValueOf(seq)
+		if found && (v.Kind() == reflect.Ptr || reflect.ValueOf(entry.seq).Len() == v.Len()) {
+			return entry.idx, nil
+		}
+	}
+
+	idx, err := collections.NewSpatialIndex(seq, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable {
+		ns.spatialIndexCacheMu.Lock()
+		ns.spatialIndexCache[key] = spatialIndexCacheEntry{idx: idx, seq: seq}
+		ns.spatialIndexCacheMu.Unlock()
+	}
+
+	return idx, nil
+}
+
+func spatialIndexCacheKeyFor(seq interface{}, fieldName string) (spatialIndexCacheKey, bool) {
+	v := reflect.ValueOf(seq)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr:
+		return spatialIndexCacheKey{seq: v.Pointer(), fieldName: fieldName}, true
+	default:
+		return spatialIndexCacheKey{}, false
+	}
+}
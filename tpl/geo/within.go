@@ -0,0 +1,43 @@
+// Copyright 2017 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"errors"
+
+	"github.com/gohugoio/hugo/tpl/collections"
+)
+
+// Within returns the subset of seq whose fieldName GeoPoint falls inside
+// bbox, a [minLat, minLon, maxLat, maxLon] bounding box.
+func (ns *Namespace) Within(seq, fieldName interface{}, bbox []float64) (interface{}, error) {
+	sortByField, ok := fieldName.(string)
+	if !ok {
+		return nil, errors.New("fieldName should be a string.")
+	}
+	if len(bbox) != 4 {
+		return nil, errors.New("bbox should be [minLat, minLon, maxLat, maxLon]")
+	}
+	return collections.WhereInBBox(seq, sortByField, bbox[0], bbox[1], bbox[2], bbox[3])
+}
+
+// WithinPolygon returns the subset of seq whose fieldName GeoPoint falls
+// inside polygon, a ring of [lon, lat] vertices.
+func (ns *Namespace) WithinPolygon(seq, fieldName interface{}, polygon [][2]float64) (interface{}, error) {
+	sortByField, ok := fieldName.(string)
+	if !ok {
+		return nil, errors.New("fieldName should be a string.")
+	}
+	return collections.WhereInPolygon(seq, sortByField, polygon)
+}